@@ -5,15 +5,16 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/leosunmo/pagerduty-shifts/pkg/calendar"
-	"github.com/leosunmo/pagerduty-shifts/pkg/config"
-	"github.com/leosunmo/pagerduty-shifts/pkg/outputs"
-	"github.com/leosunmo/pagerduty-shifts/pkg/pd"
+	"github.com/leosunmo/pagertally/pkg/calendar"
+	"github.com/leosunmo/pagertally/pkg/config"
+	"github.com/leosunmo/pagertally/pkg/outputs"
+	"github.com/leosunmo/pagertally/pkg/pd"
+	"github.com/leosunmo/pagertally/pkg/server"
 )
 
 type schedulesListFlag []string
@@ -51,16 +52,22 @@ func main() {
 	var csvfile string
 	var gsheetid string
 	var startMonth string
+	var startFlag string
+	var endFlag string
 	var timeZone string
 	var saFile string
+	var serveAddr string
 	flag.StringVar(&authtoken, "token", "", "Provide PagerDuty API token")
 	flag.Var(&schedules, "schedules", "Comma separated list of PagerDuty schedule IDs")
 	flag.StringVar(&configPath, "conf", "", "Provide config file path")
 	flag.StringVar(&csvfile, "csvfile", "", "(Optional) Print as CSV to this file")
 	flag.StringVar(&gsheetid, "gsheetid", "", "(Optional) Print to Google Sheet ID provided")
 	flag.StringVar(&saFile, "cred", "", "(Optional) Google Service Account JSON file. Required if gsheetid provided")
-	flag.StringVar(&startMonth, "month", "", "(Optional) Provide the month you want to process. Default current month")
+	flag.StringVar(&startMonth, "month", "", "(Optional) Provide the month you want to process. Default current month. Ignored if -start is provided")
+	flag.StringVar(&startFlag, "start", "", "(Optional) Start of the date range to process, as RFC3339 or 2006-01-02, in -timezone. Overrides -month")
+	flag.StringVar(&endFlag, "end", "", "(Optional) End of the date range to process, as RFC3339 or 2006-01-02, in -timezone. Required if -start is provided")
 	flag.StringVar(&timeZone, "timezone", "", "(Optional) Force timezone. Defaults to local")
+	flag.StringVar(&serveAddr, "serve", "", "(Optional) Serve shift tallies over HTTP on this address (e.g. :8080) instead of running once from the CLI. Ignores -schedules/-month/-start/-end/-csvfile/-gsheetid")
 
 	flag.Parse()
 	if authtoken == "" {
@@ -68,13 +75,22 @@ func main() {
 		flag.Usage()
 		os.Exit(1)
 	}
-	if len(schedules) < 1 {
-		fmt.Println("Please provide at least one PagerDuty schedule ID")
+	if configPath == "" {
+		fmt.Println("Please provide a config file")
 		flag.Usage()
 		os.Exit(1)
 	}
-	if configPath == "" {
-		fmt.Println("Please provide a config file")
+
+	if serveAddr != "" {
+		conf := config.GetScheduleConfig(configPath)
+		pdClient := pd.NewPDClient(authtoken)
+		srv := server.New(pdClient, conf)
+		log.Printf("Serving shift tallies on %s", serveAddr)
+		log.Fatal(http.ListenAndServe(serveAddr, srv.Router()))
+	}
+
+	if len(schedules) < 1 {
+		fmt.Println("Please provide at least one PagerDuty schedule ID")
 		flag.Usage()
 		os.Exit(1)
 	}
@@ -91,19 +107,35 @@ func main() {
 		log.Fatalf("Failed to parse timezone. use IANA TZ format, err: %s", err.Error())
 	}
 
-	// TODO: This will break if it's Jan 1 and you want to process Dec
-	if startMonth != "" {
-		startDate, err = time.ParseInLocation("January 2006", fmt.Sprintf("%s %d", startMonth, time.Now().Year()), loc)
+	var endDate time.Time
+	switch {
+	case startFlag != "":
+		if endFlag == "" {
+			fmt.Println("Please provide -end when using -start")
+			flag.Usage()
+			os.Exit(1)
+		}
+		startDate, err = parseFlagTime(startFlag, loc)
+		if err != nil {
+			log.Fatalf("Unable to parse -start, err: %s\n", err.Error())
+		}
+		endDate, err = parseFlagTime(endFlag, loc)
+		if err != nil {
+			log.Fatalf("Unable to parse -end, err: %s\n", err.Error())
+		}
+	case startMonth != "":
+		startDate, err = resolveMonthStart(startMonth, loc)
 		if err != nil {
 			log.Fatalf("Unable to parse month, err: %s\n", err.Error())
 		}
-	} else {
-		startDate, err = time.ParseInLocation("January 2006", fmt.Sprintf("%s %d", time.Now().Month(), time.Now().Year()), loc)
+		endDate = startDate.AddDate(0, 1, 0)
+	default:
+		startDate, err = resolveMonthStart(time.Now().In(loc).Month().String(), loc)
 		if err != nil {
 			log.Fatalf("Unable to parse month, err: %s\n", err.Error())
 		}
+		endDate = startDate.AddDate(0, 1, 0)
 	}
-	endDate := startDate.AddDate(0, +1, 0)
 	conf := config.GetScheduleConfig(configPath)
 	pdClient := pd.NewPDClient(authtoken)
 	cal := calendar.NewCalendar(startDate, endDate, conf)
@@ -147,7 +179,7 @@ func main() {
 			flag.Usage()
 			os.Exit(1)
 		}
-		o := outputs.NewGSheetOutput(gsheetid, startMonth+" "+strconv.Itoa(time.Now().Year()), "A1", saFile)
+		o := outputs.NewGSheetOutput(gsheetid, sheetTabName(startDate, endDate), "A1", saFile)
 		err := outputs.PrintOutput(o, fo, headers, scheduleNames)
 		if err != nil {
 			log.Fatal(err)
@@ -155,3 +187,46 @@ func main() {
 	}
 
 }
+
+// resolveMonthStart resolves a month name (e.g. "January") to the start of
+// that month in loc, assuming the most recent occurrence of it: if the named
+// month hasn't happened yet this year, it resolves to that month last year,
+// so "-month January" in February correctly means the January just gone.
+func resolveMonthStart(monthName string, loc *time.Location) (time.Time, error) {
+	return resolveMonthStartAt(monthName, loc, time.Now().In(loc))
+}
+
+// resolveMonthStartAt is resolveMonthStart with "now" passed in, so the
+// year-rollover logic can be tested without depending on the wall clock.
+func resolveMonthStartAt(monthName string, loc *time.Location, now time.Time) (time.Time, error) {
+	month, err := time.Parse("January", monthName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	year := now.Year()
+	if month.Month() > now.Month() {
+		year--
+	}
+	return time.Date(year, month.Month(), 1, 0, 0, 0, 0, loc), nil
+}
+
+// parseFlagTime parses an RFC3339 or 2006-01-02 timestamp in loc, as
+// accepted by the -start and -end flags.
+func parseFlagTime(value string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("expected RFC3339 or 2006-01-02, got %q", value)
+}
+
+// sheetTabName derives a Google Sheet tab name from a resolved date range:
+// whole calendar months get the compact "2006-01" form, everything else
+// spells out both ends, e.g. "2024-01-15..2024-01-29".
+func sheetTabName(start, end time.Time) string {
+	if start.Day() == 1 && end.Equal(start.AddDate(0, 1, 0)) {
+		return start.Format("2006-01")
+	}
+	return fmt.Sprintf("%s..%s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveMonthStart(t *testing.T) {
+	loc := time.UTC
+
+	tests := []struct {
+		name      string
+		monthName string
+		now       time.Time
+		want      time.Time
+	}{
+		{
+			name:      "month already happened this year resolves to this year",
+			monthName: "March",
+			now:       time.Date(2024, time.June, 15, 0, 0, 0, 0, loc),
+			want:      time.Date(2024, time.March, 1, 0, 0, 0, 0, loc),
+		},
+		{
+			name:      "current month resolves to this year",
+			monthName: "June",
+			now:       time.Date(2024, time.June, 15, 0, 0, 0, 0, loc),
+			want:      time.Date(2024, time.June, 1, 0, 0, 0, 0, loc),
+		},
+		{
+			name:      "month not yet happened this year resolves to last year",
+			monthName: "December",
+			now:       time.Date(2024, time.January, 1, 0, 0, 0, 0, loc),
+			want:      time.Date(2023, time.December, 1, 0, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveMonthStartAt(tt.monthName, loc, tt.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveMonthStartInvalidMonth(t *testing.T) {
+	if _, err := resolveMonthStart("Notamonth", time.UTC); err == nil {
+		t.Fatal("expected an error for an invalid month name, got nil")
+	}
+}
+
+func TestSheetTabName(t *testing.T) {
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		want  string
+	}{
+		{
+			name:  "whole calendar month",
+			start: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+			want:  "2024-01",
+		},
+		{
+			name:  "partial range spells out both ends",
+			start: time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, time.January, 29, 0, 0, 0, 0, time.UTC),
+			want:  "2024-01-15..2024-01-29",
+		},
+		{
+			name:  "month-long range not starting on the 1st spells out both ends",
+			start: time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC),
+			end:   time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC),
+			want:  "2024-01-02..2024-02-02",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sheetTabName(tt.start, tt.end); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	goical "github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+
+	"github.com/leosunmo/pagertally/pkg/config"
+)
+
+// CalDAVSource is a Source backed by a CalDAV calendar.
+type CalDAVSource struct {
+	conf *config.CalDAVConfig
+}
+
+// NewCalDAVSource returns a Source that queries a CalDAV calendar.
+func NewCalDAVSource(conf *config.CalDAVConfig) *CalDAVSource {
+	return &CalDAVSource{conf: conf}
+}
+
+// Events implements Source.
+func (s *CalDAVSource) Events(start, end time.Time) ([]Event, error) {
+	httpClient := webdav.HTTPClientWithBasicAuth(http.DefaultClient, s.conf.Username, s.conf.Password)
+	client, err := caldav.NewClient(httpClient, s.conf.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CalDAV client for %q: %w", s.conf.URL, err)
+	}
+
+	objects, err := client.QueryCalendar(context.Background(), "", eventQuery(start, end))
+	if err != nil {
+		return nil, fmt.Errorf("CalDAV calendar-query against %q failed: %w", s.conf.URL, err)
+	}
+
+	var events []Event
+	for _, obj := range objects {
+		for _, component := range obj.Data.Children {
+			if component.Name != goical.CompEvent {
+				continue
+			}
+			occurrences, err := icalEventOccurrences(goical.Event{Component: component}, start, end)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, occurrences...)
+		}
+	}
+	return events, nil
+}
+
+// eventQuery builds the calendar-query REPORT for VEVENTs within [start,
+// end]. AllProps is required on the VEVENT comp request: per RFC4791 §9.6, a
+// <C:comp> with no prop/allprop children strips properties on a conforming
+// server, which would leave DTSTART/RRULE/SUMMARY empty.
+func eventQuery(start, end time.Time) *caldav.CalendarQuery {
+	return &caldav.CalendarQuery{
+		CompRequest: caldav.CalendarCompRequest{
+			Name:  "VCALENDAR",
+			Comps: []caldav.CalendarCompRequest{{Name: "VEVENT", AllProps: true}},
+		},
+		CompFilter: caldav.CompFilter{
+			Name: "VCALENDAR",
+			Comps: []caldav.CompFilter{{
+				Name:  "VEVENT",
+				Start: start,
+				End:   end,
+			}},
+		},
+	}
+}
+
+// icalEventOccurrences expands a single VEVENT into zero or more Events
+// within [start, end].
+func icalEventOccurrences(event goical.Event, start, end time.Time) ([]Event, error) {
+	name, _ := event.Props.Text(goical.PropSummary)
+
+	dtStart, err := event.DateTimeStart(time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("event %q has no usable DTSTART: %w", name, err)
+	}
+
+	duration := 24 * time.Hour
+	if dtEnd, err := event.DateTimeEnd(time.UTC); err == nil {
+		duration = dtEnd.Sub(dtStart)
+	}
+
+	var rruleStr string
+	if rruleProp := event.Props.Get(goical.PropRecurrenceRule); rruleProp != nil {
+		rruleStr = rruleProp.Value
+	}
+
+	starts, err := expandOccurrences(dtStart, rruleStr, nil, nil, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("event %q: %w", name, err)
+	}
+
+	events := make([]Event, 0, len(starts))
+	for _, s := range starts {
+		events = append(events, Event{Name: name, Start: s, End: s.Add(duration)})
+	}
+	return events, nil
+}
@@ -0,0 +1,67 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+
+	goical "github.com/emersion/go-ical"
+)
+
+func TestEventQueryRequestsAllVEventProps(t *testing.T) {
+	query := eventQuery(mustParse(t, "2024-01-01T00:00:00Z"), mustParse(t, "2024-01-31T00:00:00Z"))
+
+	if len(query.CompRequest.Comps) != 1 || query.CompRequest.Comps[0].Name != "VEVENT" {
+		t.Fatalf("expected a single VEVENT comp request, got %+v", query.CompRequest.Comps)
+	}
+	if !query.CompRequest.Comps[0].AllProps {
+		t.Error("expected the VEVENT comp request to set AllProps, otherwise a conforming CalDAV server strips DTSTART/RRULE/SUMMARY")
+	}
+}
+
+func TestIcalEventOccurrences(t *testing.T) {
+	const icsFixture = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//pagertally//test//EN
+BEGIN:VEVENT
+UID:recurring@example.com
+DTSTAMP:20240101T000000Z
+DTSTART;VALUE=DATE:20240101
+RRULE:FREQ=WEEKLY;COUNT=3
+SUMMARY:Weekly Thing
+END:VEVENT
+END:VCALENDAR
+`
+
+	cal, err := goical.NewDecoder(strings.NewReader(icsFixture)).Decode()
+	if err != nil {
+		t.Fatalf("failed to parse fixture: %s", err.Error())
+	}
+
+	rangeStart := mustParse(t, "2024-01-01T00:00:00Z")
+	rangeEnd := mustParse(t, "2024-01-31T00:00:00Z")
+
+	var got []Event
+	for _, component := range cal.Children {
+		if component.Name != goical.CompEvent {
+			continue
+		}
+		occurrences, err := icalEventOccurrences(goical.Event{Component: component}, rangeStart, rangeEnd)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		got = append(got, occurrences...)
+	}
+
+	want := []string{"2024-01-01T00:00:00Z", "2024-01-08T00:00:00Z", "2024-01-15T00:00:00Z"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v occurrences, want %d", got, len(want))
+	}
+	for i, w := range want {
+		if !got[i].Start.Equal(mustParse(t, w)) {
+			t.Errorf("occurrence %d: got %s, want %s", i, got[i].Start, w)
+		}
+		if got[i].Name != "Weekly Thing" {
+			t.Errorf("occurrence %d: got name %q, want %q", i, got[i].Name, "Weekly Thing")
+		}
+	}
+}
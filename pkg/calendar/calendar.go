@@ -6,7 +6,6 @@ import (
 
 	timerange "github.com/leosunmo/timerange-go"
 
-	ics "github.com/leosunmo/ics-golang"
 	"github.com/leosunmo/pagertally/pkg/config"
 )
 
@@ -25,22 +24,35 @@ const StatHolidayHour int = 4
 // YmdHis is the timeformat the iCal parser expects for event dates
 const YmdHis string = "2006-01-02 15:04:05"
 
-// Calendar containsh all hours of national and
-// regional holidays (that we whitelisted) as well as
-// the configuration of whitelisted holidays and
-// business hours
+// Calendar contains the universal business/afterhours/weekend tagging for
+// every hour of the schedule, plus each Region's statutory holiday hours,
+// keyed by region name so a user's hours can be tagged against their own
+// region's public holidays via GetHourTag.
 type Calendar struct {
 	CalStart       time.Time
 	CalEnd         time.Time
 	CalDays        []time.Time
 	CalendarHours  map[string]int
+	HolidayHours   map[string]map[string]bool
 	ScheduleConfig *config.ScheduleConfig
-	CalTimezone    *time.Location
 }
 
-// NewCalendar returns an empty calendar
+// NewCalendar returns a Calendar with every hour between startDate and
+// endDate tagged, including each configured Region's statutory holidays. It
+// panics if a region's holidays can't be fetched, since a schedule can't be
+// tallied without them. Callers that can recover from a fetch failure (e.g.
+// an HTTP handler) should use NewCalendarWithError instead.
 func NewCalendar(startDate, endDate time.Time, conf *config.ScheduleConfig) *Calendar {
+	cal, err := NewCalendarWithError(startDate, endDate, conf)
+	if err != nil {
+		panic(err)
+	}
+	return cal
+}
 
+// NewCalendarWithError is NewCalendar, but returns an error instead of
+// panicking if a region's holidays can't be fetched.
+func NewCalendarWithError(startDate, endDate time.Time, conf *config.ScheduleConfig) (*Calendar, error) {
 	// Get a slice of all days between the start and end dates of the schedule
 	calDays := []time.Time{}
 	fStartDate := FlattenTime(startDate)
@@ -49,27 +61,31 @@ func NewCalendar(startDate, endDate time.Time, conf *config.ScheduleConfig) *Cal
 	for tr.Next() {
 		calDays = append(calDays, tr.Current())
 	}
-	loc, err := time.LoadLocation(conf.Timezone)
-
-	if err != nil {
-		panic("Failed loading location from timezone provided")
-	}
-	// Get the calendar timezone in second offsets
 
 	cal := Calendar{
 		CalStart:       fStartDate,
 		CalEnd:         fEndDate,
 		CalDays:        calDays,
-		CalendarHours:  make(map[string]int, 0),
+		CalendarHours:  make(map[string]int),
+		HolidayHours:   make(map[string]map[string]bool, len(conf.Regions)),
 		ScheduleConfig: conf,
-		CalTimezone:    loc,
-	}
-	err = cal.parseAndFilterPublicHolidayiCal(cal.ScheduleConfig.CalendarURL)
-	if err != nil {
-		panic(err)
 	}
 	cal.tagAfterhoursAndWeekends()
-	return &cal
+	for _, region := range conf.Regions {
+		if err := cal.loadRegionHolidays(region); err != nil {
+			return nil, err
+		}
+	}
+	return &cal, nil
+}
+
+// newHolidaySource picks the holiday Source for a region: a CalDAV calendar
+// if credentials are configured, otherwise the legacy static ICS feed.
+func newHolidaySource(region config.Region) Source {
+	if region.CalDAV != nil {
+		return NewCalDAVSource(region.CalDAV)
+	}
+	return NewICSSource(region.CalendarURL)
 }
 
 func (c *Calendar) GetBusinessHours() (time.Time, time.Time) {
@@ -81,55 +97,43 @@ func (c *Calendar) addHour(hourStart time.Time, hourType int) {
 	c.CalendarHours[hourStart.Format(time.RFC3339)] = hourType
 }
 
-func (c *Calendar) parseAndFilterPublicHolidayiCal(icsLink string) error {
-	//  create new parser
-	parser := ics.New()
-
-	// get the input chan
-	inputChan := parser.GetInputChan()
-
-	// send the calendar urls to be parsed
-	//inputChan <- "http://apps.employment.govt.nz/ical/public-holidays-all.ics"
-	inputChan <- icsLink
-	//  wait for the calendar to be parsed
-	parser.Wait()
+func (c *Calendar) addHolidayHour(region string, hourStart time.Time) {
+	hours, ok := c.HolidayHours[region]
+	if !ok {
+		hours = make(map[string]bool)
+		c.HolidayHours[region] = hours
+	}
+	hours[hourStart.Format(time.RFC3339)] = true
+}
 
-	// get all calendars in this parser
-	cals, err := parser.GetCalendars()
+// loadRegionHolidays pulls every event from region's Source, keeps the ones
+// whitelisted in region.Holidays, and tags each hour they cover as a
+// statutory holiday for that region.
+func (c *Calendar) loadRegionHolidays(region config.Region) error {
+	events, err := newHolidaySource(region).Events(c.CalStart, c.CalEnd)
 	if err != nil {
-		return fmt.Errorf("Failed to parse iCal")
+		return fmt.Errorf("region %q: failed to fetch holiday events: %w", region.Name, err)
 	}
-	for _, cal := range cals {
-		eventsByDates := cal.GetEventsByDates()
-		for _, schedDay := range c.CalDays {
-			schedDay = FlattenTime(schedDay)
-			events, exists := eventsByDates[schedDay.Format(YmdHis)]
-			if !exists {
-				continue
-			}
-			for _, event := range events {
-				// See if event is in event whitelist
-				if c.filterEvent(event.GetSummary()) {
-					// Start iterating over every hour of the event and add those hours as stat days
-					eventFlatStart := FlattenTime(event.GetStart())
-					eventFlatEnd := FlattenTime(event.GetEnd())
-					tr := timerange.New(eventFlatStart, eventFlatEnd.Add(time.Duration(-1)*time.Hour), time.Hour)
-					for tr.Next() {
-						adjustedTime := AdjustForTimezone(tr.Current(), c.ScheduleConfig.ParsedTimezone)
-						c.addHour(adjustedTime, StatHolidayHour)
-					}
-				}
-			}
+
+	for _, event := range events {
+		if !filterEvent(region.Holidays, event.Name) {
+			continue
+		}
+		eventFlatStart := FlattenTime(event.Start)
+		eventFlatEnd := FlattenTime(event.End)
+		tr := timerange.New(eventFlatStart, eventFlatEnd.Add(time.Duration(-1)*time.Hour), time.Hour)
+		for tr.Next() {
+			adjustedTime := AdjustForTimezone(tr.Current(), region.ParsedTimezone)
+			c.addHolidayHour(region.Name, adjustedTime)
 		}
 	}
 	return nil
 }
 
-// filterEvent compares the given event name against the whitelist of events
-// specified in the config.
-// returns true if it's whitelisted, false if it should be ignored
-func (c *Calendar) filterEvent(eventName string) bool {
-	for _, h := range c.ScheduleConfig.Holidays {
+// filterEvent compares the given event name against a region's whitelist of
+// holidays. Returns true if it's whitelisted, false if it should be ignored.
+func filterEvent(whitelist []string, eventName string) bool {
+	for _, h := range whitelist {
 		if eventName == h {
 			return true
 		}
@@ -143,47 +147,43 @@ func (c *Calendar) tagAfterhoursAndWeekends() {
 		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
 			tr := timerange.New(day, day.Add(time.Hour*24), time.Hour)
 			for tr.Next() {
-				if c.CalendarHours[FlattenTime(tr.Current()).Format(time.RFC3339)] != StatHolidayHour {
-					c.addHour(FlattenTime(tr.Current()), WeekendHour)
-				}
+				c.addHour(FlattenTime(tr.Current()), WeekendHour)
 			}
 			continue
 		}
 		// Add afterhours from start of day (00:01) to start of business hours (eg. 09:00)
 		tr := timerange.New(day, day.Add(time.Hour*time.Duration(bStart.Hour())), time.Hour)
 		for tr.Next() {
-			if c.CalendarHours[FlattenTime(tr.Current()).Format(time.RFC3339)] != StatHolidayHour {
-				c.addHour(FlattenTime(tr.Current()), BusinessAfterHour)
-			}
+			c.addHour(FlattenTime(tr.Current()), BusinessAfterHour)
 		}
 		// Add afterhours from business hours end (eg. 17:00) to end of day (day + 23 hours to avoid adding an extra hour at the end of the day)
 		// unless it's Friday, then it's weekend hours.
 		if day.Weekday() != time.Friday {
 			tr = timerange.New(day.Add(time.Hour*time.Duration(bEnd.Hour())), day.Add(time.Hour*23), time.Hour)
 			for tr.Next() {
-				if c.CalendarHours[FlattenTime(tr.Current()).Format(time.RFC3339)] != StatHolidayHour {
-					c.addHour(FlattenTime(tr.Current()), BusinessAfterHour)
-				}
+				c.addHour(FlattenTime(tr.Current()), BusinessAfterHour)
 			}
 		} else {
 			tr := timerange.New(day.Add(time.Hour*time.Duration(bEnd.Hour())), day.Add(time.Hour*24), time.Hour)
 			for tr.Next() {
-				if c.CalendarHours[FlattenTime(tr.Current()).Format(time.RFC3339)] != StatHolidayHour {
-					c.addHour(FlattenTime(tr.Current()), WeekendHour)
-				}
+				c.addHour(FlattenTime(tr.Current()), WeekendHour)
 			}
 		}
 	}
 }
 
-// GetHourTag returns the hour type of the timestamp provided
-func (c *Calendar) GetHourTag(h time.Time) int {
+// GetHourTag returns the hour type of the timestamp provided for the given
+// region: StatHolidayHour if that region observes a statutory holiday then,
+// otherwise the universal business/afterhours/weekend tag.
+func (c *Calendar) GetHourTag(region string, h time.Time) int {
+	if hours, ok := c.HolidayHours[region]; ok && hours[h.Format(time.RFC3339)] {
+		return StatHolidayHour
+	}
 	hourType, exists := c.CalendarHours[h.Format(time.RFC3339)]
 	if !exists {
 		return BusinessHour
 	}
 	return hourType
-
 }
 
 func timeWithinTimeRange(start time.Time, end time.Time, timestamp time.Time) bool {
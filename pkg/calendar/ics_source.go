@@ -0,0 +1,120 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// icsDateTimeLayouts are the DATE-TIME/DATE layouts used by DTSTART, DTEND,
+// RDATE and EXDATE values in an iCal feed. We don't care about floating vs.
+// UTC vs. TZID-qualified times here, we only need the naive wall-clock value.
+var icsDateTimeLayouts = []string{"20060102T150405Z", "20060102T150405", "20060102"}
+
+func parseICSTime(value string) (time.Time, error) {
+	for _, layout := range icsDateTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised iCal date-time %q", value)
+}
+
+// ICSSource fetches and parses a static ICS feed over HTTP, expanding any
+// RRULE (together with its RDATE/EXDATE instances) against the requested
+// range. RDATE and EXDATE on an event without an RRULE are not applied. This
+// is the original, and still default, way of sourcing holidays.
+type ICSSource struct {
+	URL string
+}
+
+// NewICSSource returns a Source that fetches holidays from a static ICS feed.
+func NewICSSource(url string) *ICSSource {
+	return &ICSSource{URL: url}
+}
+
+// Events implements Source.
+func (s *ICSSource) Events(start, end time.Time) ([]Event, error) {
+	resp, err := http.Get(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch iCal %q: %w", s.URL, err)
+	}
+	defer resp.Body.Close()
+
+	cal, err := ics.ParseCalendar(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse iCal: %w", err)
+	}
+
+	var events []Event
+	for _, vevent := range cal.Events() {
+		occurrences, err := icsEventOccurrences(vevent, start, end)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, occurrences...)
+	}
+	return events, nil
+}
+
+// icsEventOccurrences expands a single VEVENT into zero or more Events
+// within [start, end].
+func icsEventOccurrences(vevent *ics.VEvent, start, end time.Time) ([]Event, error) {
+	name := ""
+	if summaryProp := vevent.GetProperty(ics.ComponentPropertySummary); summaryProp != nil {
+		name = summaryProp.Value
+	}
+
+	dtStartProp := vevent.GetProperty(ics.ComponentPropertyDtStart)
+	if dtStartProp == nil {
+		return nil, fmt.Errorf("event %q has no DTSTART", name)
+	}
+	dtStart, err := parseICSTime(dtStartProp.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	// Statutory holidays are usually all-day VEVENTs with no DTEND, so
+	// default to a single day and refine it if a DTEND is actually present.
+	duration := 24 * time.Hour
+	if dtEndProp := vevent.GetProperty(ics.ComponentPropertyDtEnd); dtEndProp != nil {
+		dtEnd, err := parseICSTime(dtEndProp.Value)
+		if err != nil {
+			return nil, err
+		}
+		duration = dtEnd.Sub(dtStart)
+	}
+
+	var rruleStr string
+	if rruleProp := vevent.GetProperty(ics.ComponentPropertyRrule); rruleProp != nil {
+		rruleStr = rruleProp.Value
+	}
+	rdates := parseICSDateList(vevent.GetProperties(ics.ComponentPropertyRdate))
+	exdates := parseICSDateList(vevent.GetProperties(ics.ComponentPropertyExdate))
+
+	starts, err := expandOccurrences(dtStart, rruleStr, rdates, exdates, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("event %q: %w", name, err)
+	}
+
+	events := make([]Event, 0, len(starts))
+	for _, s := range starts {
+		events = append(events, Event{Name: name, Start: s, End: s.Add(duration)})
+	}
+	return events, nil
+}
+
+func parseICSDateList(props []*ics.IANAProperty) []time.Time {
+	var dates []time.Time
+	for _, prop := range props {
+		for _, value := range strings.Split(prop.Value, ",") {
+			if t, err := parseICSTime(value); err == nil {
+				dates = append(dates, t)
+			}
+		}
+	}
+	return dates
+}
@@ -0,0 +1,77 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	ics "github.com/arran4/golang-ical"
+)
+
+// icsFixture is a minimal but realistic ICS feed: one plain all-day VEVENT
+// and one VEVENT with an RRULE/RDATE/EXDATE. Parsing this through
+// ics.ParseCalendar and feeding the result into icsEventOccurrences exercises
+// vevent.GetProperties' real return type, which is what a table-driven unit
+// test against parseICSDateList alone would not catch.
+const icsFixture = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//pagertally//test//EN
+BEGIN:VEVENT
+UID:single@example.com
+DTSTAMP:20240101T000000Z
+DTSTART;VALUE=DATE:20240115
+SUMMARY:New Year Observed
+END:VEVENT
+BEGIN:VEVENT
+UID:recurring@example.com
+DTSTAMP:20240101T000000Z
+DTSTART;VALUE=DATE:20240101
+RRULE:FREQ=WEEKLY;COUNT=3
+RDATE;VALUE=DATE:20240120
+EXDATE;VALUE=DATE:20240108
+SUMMARY:Weekly Thing
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestICSEventOccurrences(t *testing.T) {
+	cal, err := ics.ParseCalendar(strings.NewReader(icsFixture))
+	if err != nil {
+		t.Fatalf("failed to parse fixture: %s", err.Error())
+	}
+
+	rangeStart := mustParse(t, "2024-01-01T00:00:00Z")
+	rangeEnd := mustParse(t, "2024-01-31T00:00:00Z")
+
+	var got []Event
+	for _, vevent := range cal.Events() {
+		occurrences, err := icsEventOccurrences(vevent, rangeStart, rangeEnd)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		got = append(got, occurrences...)
+	}
+
+	want := []time.Time{
+		mustParse(t, "2024-01-15T00:00:00Z"), // the standalone "New Year Observed" VEVENT
+		mustParse(t, "2024-01-01T00:00:00Z"),
+		mustParse(t, "2024-01-15T00:00:00Z"), // the weekly RRULE also lands here
+		mustParse(t, "2024-01-20T00:00:00Z"), // added by RDATE
+		// 2024-01-08, the RRULE's second occurrence, is removed by EXDATE.
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d occurrences, want %d: %v", len(got), len(want), got)
+	}
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			if g.Start.Equal(w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing expected occurrence %s in %v", w, got)
+		}
+	}
+}
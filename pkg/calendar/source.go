@@ -0,0 +1,74 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// Event is a single calendar occurrence, already expanded out of any
+// recurrence rule, ready to be matched against a schedule's holiday
+// whitelist.
+type Event struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+// Source fetches the calendar events that fall within [start, end]. It's
+// the extension point between Calendar and wherever holidays actually live:
+// a static ICS feed, a CalDAV server, or anything else.
+type Source interface {
+	Events(start, end time.Time) ([]Event, error)
+}
+
+// expandOccurrences turns a single VEVENT (given as a DTSTART, an optional
+// RRULE and any RDATE/EXDATE instances) into the list of occurrence starts
+// that fall within [rangeStart, rangeEnd]. Events with no RRULE are returned
+// as their single DTSTART occurrence, provided it's in range.
+//
+// The range is buffered by a day on each side before matching, since
+// AdjustForTimezone (applied by callers afterwards) can shift an occurrence
+// landing right on rangeStart/rangeEnd across the boundary; the buffer is
+// applied identically to the RRULE and non-RRULE paths so neither silently
+// drops a boundary occurrence the other would have kept.
+func expandOccurrences(dtStart time.Time, rruleStr string, rdates, exdates []time.Time, rangeStart, rangeEnd time.Time) ([]time.Time, error) {
+	bufferedStart := rangeStart.AddDate(0, 0, -1)
+	bufferedEnd := rangeEnd.AddDate(0, 0, 1)
+
+	if rruleStr == "" {
+		if dateWithinDateRange(bufferedStart, bufferedEnd, dtStart) {
+			return []time.Time{dtStart}, nil
+		}
+		return nil, nil
+	}
+
+	option, err := rrule.StrToROption(rruleStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RRULE %q: %w", rruleStr, err)
+	}
+	option.Dtstart = dtStart
+	rule, err := rrule.NewRRule(*option)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build rrule: %w", err)
+	}
+	occurrences := rule.Between(bufferedStart, bufferedEnd, true)
+	for _, rdate := range rdates {
+		if dateWithinDateRange(bufferedStart, bufferedEnd, rdate) {
+			occurrences = append(occurrences, rdate)
+		}
+	}
+
+	exdateSet := make(map[time.Time]bool, len(exdates))
+	for _, e := range exdates {
+		exdateSet[e] = true
+	}
+	filtered := occurrences[:0]
+	for _, o := range occurrences {
+		if !exdateSet[o] {
+			filtered = append(filtered, o)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,119 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, value string) time.Time {
+	t.Helper()
+	tm, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		t.Fatalf("failed to parse %q: %s", value, err.Error())
+	}
+	return tm
+}
+
+func TestExpandOccurrences(t *testing.T) {
+	rangeStart := mustParse(t, "2024-01-01T00:00:00Z")
+	rangeEnd := mustParse(t, "2024-01-31T00:00:00Z")
+
+	tests := []struct {
+		name    string
+		dtStart time.Time
+		rrule   string
+		rdates  []time.Time
+		exdates []time.Time
+		want    []time.Time
+	}{
+		{
+			name:    "single occurrence in range",
+			dtStart: mustParse(t, "2024-01-15T00:00:00Z"),
+			want:    []time.Time{mustParse(t, "2024-01-15T00:00:00Z")},
+		},
+		{
+			name:    "single occurrence out of range",
+			dtStart: mustParse(t, "2024-03-01T00:00:00Z"),
+			want:    nil,
+		},
+		{
+			name:    "single occurrence on the last day of range is kept by the buffer",
+			dtStart: rangeEnd,
+			want:    []time.Time{rangeEnd},
+		},
+		{
+			name:    "weekly rrule expands within range",
+			dtStart: mustParse(t, "2024-01-01T00:00:00Z"),
+			rrule:   "FREQ=WEEKLY;COUNT=3",
+			want: []time.Time{
+				mustParse(t, "2024-01-01T00:00:00Z"),
+				mustParse(t, "2024-01-08T00:00:00Z"),
+				mustParse(t, "2024-01-15T00:00:00Z"),
+			},
+		},
+		{
+			name:    "rrule occurrence on the last day of range is kept by the buffer",
+			dtStart: rangeEnd,
+			rrule:   "FREQ=YEARLY;COUNT=1",
+			want:    []time.Time{rangeEnd},
+		},
+		{
+			name:    "rdate adds an extra occurrence within the buffered range",
+			dtStart: mustParse(t, "2024-01-01T00:00:00Z"),
+			rrule:   "FREQ=WEEKLY;COUNT=1",
+			rdates:  []time.Time{mustParse(t, "2024-01-20T00:00:00Z")},
+			want: []time.Time{
+				mustParse(t, "2024-01-01T00:00:00Z"),
+				mustParse(t, "2024-01-20T00:00:00Z"),
+			},
+		},
+		{
+			name:    "rdate outside the buffered range is dropped",
+			dtStart: mustParse(t, "2024-01-01T00:00:00Z"),
+			rrule:   "FREQ=WEEKLY;COUNT=1",
+			rdates:  []time.Time{mustParse(t, "2024-06-01T00:00:00Z")},
+			want:    []time.Time{mustParse(t, "2024-01-01T00:00:00Z")},
+		},
+		{
+			name:    "exdate removes a matching occurrence",
+			dtStart: mustParse(t, "2024-01-01T00:00:00Z"),
+			rrule:   "FREQ=WEEKLY;COUNT=3",
+			exdates: []time.Time{mustParse(t, "2024-01-08T00:00:00Z")},
+			want: []time.Time{
+				mustParse(t, "2024-01-01T00:00:00Z"),
+				mustParse(t, "2024-01-15T00:00:00Z"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandOccurrences(tt.dtStart, tt.rrule, tt.rdates, tt.exdates, rangeStart, rangeEnd)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err.Error())
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v occurrences, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d: got %s, want %s", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExpandOccurrencesInvalidRRule(t *testing.T) {
+	_, err := expandOccurrences(
+		mustParse(t, "2024-01-01T00:00:00Z"),
+		"NOT-A-VALID-RRULE",
+		nil, nil,
+		mustParse(t, "2024-01-01T00:00:00Z"),
+		mustParse(t, "2024-01-31T00:00:00Z"),
+	)
+	if err == nil {
+		t.Fatal("expected an error for an invalid RRULE, got nil")
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// BusinessHours defines the start and end of the business day, in
+// "2006-01-02 15:04:05" format, used to classify hours as business hours or
+// afterhours.
+type BusinessHours struct {
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+}
+
+// CalDAVConfig holds the connection details for a CalDAV calendar used as a
+// holiday source, as an alternative to a static CalendarURL ICS feed.
+type CalDAVConfig struct {
+	URL      string `yaml:"url"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// Region is a named holiday calendar: a timezone and a whitelist of holidays.
+type Region struct {
+	Name           string `yaml:"name"`
+	Timezone       string `yaml:"timezone"`
+	ParsedTimezone *time.Location
+	CalendarURL    string `yaml:"calendarUrl"`
+	// CalDAV, when set, is used to source this region's holidays instead of
+	// CalendarURL.
+	CalDAV   *CalDAVConfig `yaml:"caldav"`
+	Holidays []string      `yaml:"holidays"`
+}
+
+// ScheduleConfig holds everything needed to classify the hours of a
+// PagerDuty schedule: the business hours, and the holiday Regions whose
+// statutory holidays should be tagged against each user's hours.
+type ScheduleConfig struct {
+	BusinessHours BusinessHours `yaml:"businessHours"`
+	Regions       []Region      `yaml:"regions"`
+	// UserRegions maps a PagerDuty user, by email or by "@domain", to the
+	// Region name whose holidays apply to their hours. Users that don't
+	// match an entry fall back to DefaultRegion.
+	UserRegions   map[string]string `yaml:"userRegions"`
+	DefaultRegion string            `yaml:"defaultRegion"`
+}
+
+// RegionForUser returns the Region name that should classify email's hours:
+// an explicit UserRegions entry, an "@domain" match, or DefaultRegion.
+// Matching is case-insensitive since email addresses (and especially their
+// domain part) are.
+func (c *ScheduleConfig) RegionForUser(email string) string {
+	email = strings.ToLower(email)
+	if region, ok := c.UserRegions[email]; ok {
+		return region
+	}
+	if at := strings.IndexByte(email, '@'); at >= 0 {
+		if region, ok := c.UserRegions["@"+email[at+1:]]; ok {
+			return region
+		}
+	}
+	return c.DefaultRegion
+}
+
+// GetScheduleConfig reads and parses the schedule config file at path,
+// resolving each Region's Timezone into ParsedTimezone along the way. It
+// panics on any failure since a schedule can't be processed without a valid
+// config.
+func GetScheduleConfig(path string) *ScheduleConfig {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		panic(fmt.Sprintf("failed to read config file %q: %s", path, err.Error()))
+	}
+
+	conf := &ScheduleConfig{}
+	if err := yaml.Unmarshal(data, conf); err != nil {
+		panic(fmt.Sprintf("failed to parse config file %q: %s", path, err.Error()))
+	}
+	if len(conf.Regions) == 0 {
+		panic(fmt.Sprintf("config file %q has no regions configured; it may still be using the old flat timezone/calendarUrl/holidays keys", path))
+	}
+
+	for i := range conf.Regions {
+		loc, err := time.LoadLocation(conf.Regions[i].Timezone)
+		if err != nil {
+			panic(fmt.Sprintf("failed to parse timezone %q for region %q: %s", conf.Regions[i].Timezone, conf.Regions[i].Name, err.Error()))
+		}
+		conf.Regions[i].ParsedTimezone = loc
+	}
+
+	// Normalise keys so RegionForUser's case-insensitive lookups always hit.
+	if conf.UserRegions != nil {
+		normalised := make(map[string]string, len(conf.UserRegions))
+		for k, v := range conf.UserRegions {
+			normalised[strings.ToLower(k)] = v
+		}
+		conf.UserRegions = normalised
+	}
+
+	regionNames := make(map[string]bool, len(conf.Regions))
+	for _, region := range conf.Regions {
+		regionNames[region.Name] = true
+	}
+	if conf.DefaultRegion != "" && !regionNames[conf.DefaultRegion] {
+		panic(fmt.Sprintf("config file %q: defaultRegion %q doesn't match any configured region", path, conf.DefaultRegion))
+	}
+	for user, region := range conf.UserRegions {
+		if !regionNames[region] {
+			panic(fmt.Sprintf("config file %q: userRegions[%q] = %q doesn't match any configured region", path, user, region))
+		}
+	}
+
+	return conf
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestRegionForUser(t *testing.T) {
+	conf := &ScheduleConfig{
+		UserRegions: map[string]string{
+			"alice@example.com": "nz",
+			"@example.org":      "uk",
+		},
+		DefaultRegion: "us",
+	}
+
+	tests := []struct {
+		name  string
+		email string
+		want  string
+	}{
+		{name: "explicit email match", email: "alice@example.com", want: "nz"},
+		{name: "explicit email match is case-insensitive", email: "Alice@Example.com", want: "nz"},
+		{name: "domain match", email: "bob@example.org", want: "uk"},
+		{name: "domain match is case-insensitive", email: "bob@Example.ORG", want: "uk"},
+		{name: "no match falls back to default", email: "carol@other.com", want: "us"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conf.RegionForUser(tt.email); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetScheduleConfigRejectsUnknownDefaultRegion(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected GetScheduleConfig to panic on an unknown defaultRegion")
+		}
+	}()
+	GetScheduleConfig(writeTempConfig(t, `
+regions:
+  - name: nz
+    timezone: Pacific/Auckland
+defaultRegion: doesnotexist
+`))
+}
+
+func TestGetScheduleConfigRejectsUnknownUserRegion(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected GetScheduleConfig to panic on an unknown userRegions value")
+		}
+	}()
+	GetScheduleConfig(writeTempConfig(t, `
+regions:
+  - name: nz
+    timezone: Pacific/Auckland
+userRegions:
+  alice@example.com: doesnotexist
+`))
+}
+
+func TestGetScheduleConfigRejectsEmptyRegions(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected GetScheduleConfig to panic on a config with no regions")
+		}
+	}()
+	GetScheduleConfig(writeTempConfig(t, `
+businessHours:
+  start: "2006-01-02 09:00:00"
+  end: "2006-01-02 17:00:00"
+`))
+}
+
+func writeTempConfig(t *testing.T, contents string) string {
+	t.Helper()
+	f := t.TempDir() + "/config.yaml"
+	if err := ioutil.WriteFile(f, []byte(contents), 0o600); err != nil {
+		t.Fatalf("failed to write temp config: %s", err.Error())
+	}
+	return f
+}
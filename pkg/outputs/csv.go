@@ -0,0 +1,47 @@
+package outputs
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+)
+
+// CSVOutput writes a tally to a local CSV file.
+type CSVOutput struct {
+	path string
+}
+
+// NewCSVOutput returns an Output that writes to the file at path,
+// overwriting it if it already exists.
+func NewCSVOutput(path string) *CSVOutput {
+	return &CSVOutput{path: path}
+}
+
+// Write implements Output.
+func (c *CSVOutput) Write(headers []interface{}, rows [][]interface{}) error {
+	f, err := os.Create(c.path)
+	if err != nil {
+		return fmt.Errorf("failed to create csv file %q: %w", c.path, err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.Write(toStrings(headers)); err != nil {
+		return fmt.Errorf("failed to write csv headers: %w", err)
+	}
+	for _, row := range rows {
+		if err := w.Write(toStrings(row)); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func toStrings(values []interface{}) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = fmt.Sprint(v)
+	}
+	return out
+}
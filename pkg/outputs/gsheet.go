@@ -0,0 +1,42 @@
+package outputs
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// GSheetOutput writes a tally into a tab of a Google Sheet.
+type GSheetOutput struct {
+	sheetID  string
+	tab      string
+	cell     string
+	credFile string
+}
+
+// NewGSheetOutput returns an Output that writes to the given Google Sheet
+// ID, starting at cell (e.g. "A1") of tab, authenticating with the Google
+// Service Account credentials at credFile.
+func NewGSheetOutput(sheetID, tab, cell, credFile string) *GSheetOutput {
+	return &GSheetOutput{sheetID: sheetID, tab: tab, cell: cell, credFile: credFile}
+}
+
+// Write implements Output.
+func (g *GSheetOutput) Write(headers []interface{}, rows [][]interface{}) error {
+	ctx := context.Background()
+	srv, err := sheets.NewService(ctx, option.WithCredentialsFile(g.credFile))
+	if err != nil {
+		return fmt.Errorf("failed to create google sheets client: %w", err)
+	}
+
+	values := append([][]interface{}{headers}, rows...)
+	_, err = srv.Spreadsheets.Values.Update(g.sheetID, fmt.Sprintf("%s!%s", g.tab, g.cell), &sheets.ValueRange{
+		Values: values,
+	}).ValueInputOption("RAW").Do()
+	if err != nil {
+		return fmt.Errorf("failed to write to google sheet %q: %w", g.sheetID, err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package outputs
+
+import (
+	"fmt"
+
+	"github.com/leosunmo/pagertally/pkg/calendar"
+)
+
+// Output is a destination a shift tally can be written to.
+type Output interface {
+	Write(headers []interface{}, rows [][]interface{}) error
+}
+
+// PrintOutput writes headers followed by one row per user in fo to o.
+func PrintOutput(o Output, fo map[string]FinalOutput, headers []interface{}, scheduleNames []string) error {
+	rows := make([][]interface{}, 0, len(fo))
+	for user, f := range fo {
+		rows = append(rows, []interface{}{
+			user, f.BusinessHours, f.AfterHours, f.WeekendHours, f.StatHours,
+			f.TotalHours, f.TotalShifts, calendar.SheetDurationFormat(f.TotalDuration),
+		})
+	}
+	if err := o.Write(headers, rows); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
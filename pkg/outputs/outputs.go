@@ -0,0 +1,45 @@
+package outputs
+
+import (
+	"time"
+
+	"github.com/leosunmo/pagertally/pkg/pd"
+)
+
+// FinalOutput is the aggregated hours and shift count for a single user,
+// across every schedule they were on.
+type FinalOutput struct {
+	BusinessHours int
+	AfterHours    int
+	WeekendHours  int
+	StatHours     int
+	TotalHours    int
+	TotalShifts   int
+	TotalDuration time.Duration
+}
+
+// CalculateFinalOutput aggregates every schedule's per-user shifts into a
+// single FinalOutput per user, and returns the schedule names that were
+// aggregated.
+func CalculateFinalOutput(totalUserShifts pd.ScheduleUserShifts) (map[string]FinalOutput, []string) {
+	fo := map[string]FinalOutput{}
+	scheduleNames := make([]string, 0, len(totalUserShifts))
+
+	for scheduleName, userShifts := range totalUserShifts {
+		scheduleNames = append(scheduleNames, scheduleName)
+		for user, shifts := range userShifts {
+			o := fo[user]
+			for _, shift := range shifts {
+				o.BusinessHours += shift.BusinessHours
+				o.AfterHours += shift.AfterHours
+				o.WeekendHours += shift.WeekendHours
+				o.StatHours += shift.StatHours
+				o.TotalDuration += shift.End.Sub(shift.Start)
+				o.TotalShifts++
+			}
+			o.TotalHours = o.BusinessHours + o.AfterHours + o.WeekendHours + o.StatHours
+			fo[user] = o
+		}
+	}
+	return fo, scheduleNames
+}
@@ -0,0 +1,132 @@
+package pd
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+
+	timerange "github.com/leosunmo/timerange-go"
+
+	"github.com/leosunmo/pagertally/pkg/calendar"
+	"github.com/leosunmo/pagertally/pkg/config"
+)
+
+// emailCacheTTL bounds how long a user ID -> email lookup is trusted before
+// Client re-fetches it, so a user's email change is eventually picked up by
+// a long-running server instead of being cached for the process's lifetime.
+const emailCacheTTL = time.Hour
+
+type cachedEmail struct {
+	email     string
+	fetchedAt time.Time
+}
+
+// Client wraps the PagerDuty API client used to read schedule shifts. It
+// caches user ID -> email lookups across calls, since the same users tend to
+// recur across schedules and, in server mode, across requests.
+type Client struct {
+	client *pagerduty.Client
+
+	emailsMu   sync.Mutex
+	userEmails map[string]cachedEmail
+}
+
+// NewPDClient returns a Client authenticated with the given PagerDuty API token.
+func NewPDClient(authtoken string) *Client {
+	return &Client{
+		client:     pagerduty.NewClient(authtoken),
+		userEmails: make(map[string]cachedEmail),
+	}
+}
+
+// emailFor returns the email address of the PagerDuty user with the given
+// ID, fetching and caching it on c if it isn't already known or if the
+// cached entry has exceeded emailCacheTTL.
+func (c *Client) emailFor(userID string) (string, error) {
+	c.emailsMu.Lock()
+	cached, ok := c.userEmails[userID]
+	c.emailsMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < emailCacheTTL {
+		return cached.email, nil
+	}
+
+	user, err := c.client.GetUser(userID, pagerduty.GetUserOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch user %q: %w", userID, err)
+	}
+
+	c.emailsMu.Lock()
+	c.userEmails[userID] = cachedEmail{email: user.Email, fetchedAt: time.Now()}
+	c.emailsMu.Unlock()
+	return user.Email, nil
+}
+
+// Shift is a single on-call shift, tagged with how many hours of each type
+// (business, afterhours, weekend, stat holiday) it covered.
+type Shift struct {
+	Start         time.Time
+	End           time.Time
+	BusinessHours int
+	AfterHours    int
+	WeekendHours  int
+	StatHours     int
+}
+
+// UserShifts is every shift worked by a single PagerDuty user, keyed by
+// their name.
+type UserShifts map[string][]Shift
+
+// ScheduleUserShifts maps a schedule's name to the shifts worked within it,
+// per user.
+type ScheduleUserShifts map[string]UserShifts
+
+// ReadShifts fetches every on-call shift for scheduleID between start and
+// end, tags each hour against cal, and returns the schedule's name along
+// with every user's shifts.
+func ReadShifts(c *Client, conf *config.ScheduleConfig, cal *calendar.Calendar, scheduleID string, start, end time.Time) (string, UserShifts, error) {
+	schedule, err := c.client.GetSchedule(scheduleID, pagerduty.GetScheduleOptions{
+		Since: start.Format(time.RFC3339),
+		Until: end.Format(time.RFC3339),
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch schedule %q: %w", scheduleID, err)
+	}
+
+	userShifts := UserShifts{}
+	for _, entry := range schedule.FinalSchedule.RenderedScheduleEntries {
+		entryStart, err := time.Parse(time.RFC3339, entry.Start)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse shift start %q: %w", entry.Start, err)
+		}
+		entryEnd, err := time.Parse(time.RFC3339, entry.End)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to parse shift end %q: %w", entry.End, err)
+		}
+
+		email, err := c.emailFor(entry.User.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		region := conf.RegionForUser(email)
+
+		shift := Shift{Start: entryStart, End: entryEnd}
+		tr := timerange.New(calendar.FlattenTime(entryStart), calendar.FlattenTime(entryEnd), time.Hour)
+		for tr.Next() {
+			switch cal.GetHourTag(region, tr.Current()) {
+			case calendar.BusinessHour:
+				shift.BusinessHours++
+			case calendar.BusinessAfterHour:
+				shift.AfterHours++
+			case calendar.WeekendHour:
+				shift.WeekendHours++
+			case calendar.StatHolidayHour:
+				shift.StatHours++
+			}
+		}
+		userShifts[entry.User.Summary] = append(userShifts[entry.User.Summary], shift)
+	}
+
+	return schedule.Name, userShifts, nil
+}
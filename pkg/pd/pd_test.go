@@ -0,0 +1,72 @@
+package pd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+func newTestClient(t *testing.T, requestCount *int32) (*Client, func()) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requestCount, 1)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"user": map[string]interface{}{"id": "U1", "email": "user1@example.com"},
+		})
+	}))
+
+	return &Client{
+		client:     pagerduty.NewClient("test-token", pagerduty.WithAPIEndpoint(server.URL)),
+		userEmails: make(map[string]cachedEmail),
+	}, server.Close
+}
+
+func TestClientEmailForCaches(t *testing.T) {
+	var requestCount int32
+	c, closeServer := newTestClient(t, &requestCount)
+	defer closeServer()
+
+	for i := 0; i < 3; i++ {
+		email, err := c.emailFor("U1")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err.Error())
+		}
+		if email != "user1@example.com" {
+			t.Errorf("got email %q, want %q", email, "user1@example.com")
+		}
+	}
+
+	if got := atomic.LoadInt32(&requestCount); got != 1 {
+		t.Errorf("expected exactly 1 GetUser request across repeated lookups, got %d", got)
+	}
+}
+
+func TestClientEmailForRefetchesAfterTTL(t *testing.T) {
+	var requestCount int32
+	c, closeServer := newTestClient(t, &requestCount)
+	defer closeServer()
+
+	if _, err := c.emailFor("U1"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	c.emailsMu.Lock()
+	stale := c.userEmails["U1"]
+	stale.fetchedAt = time.Now().Add(-2 * emailCacheTTL)
+	c.userEmails["U1"] = stale
+	c.emailsMu.Unlock()
+
+	if _, err := c.emailFor("U1"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if got := atomic.LoadInt32(&requestCount); got != 2 {
+		t.Errorf("expected the stale entry to trigger a re-fetch, got %d requests", got)
+	}
+}
@@ -0,0 +1,150 @@
+package server
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/leosunmo/pagertally/pkg/calendar"
+	"github.com/leosunmo/pagertally/pkg/config"
+	"github.com/leosunmo/pagertally/pkg/outputs"
+	"github.com/leosunmo/pagertally/pkg/pd"
+)
+
+// Server serves shift tallies over HTTP.
+type Server struct {
+	pdClient *pd.Client
+	conf     *config.ScheduleConfig
+
+	mu    sync.Mutex
+	cache map[string]*calendar.Calendar
+}
+
+// New returns a Server that queries PagerDuty through pdClient, classifying
+// hours against conf.
+func New(pdClient *pd.Client, conf *config.ScheduleConfig) *Server {
+	return &Server{
+		pdClient: pdClient,
+		conf:     conf,
+		cache:    make(map[string]*calendar.Calendar),
+	}
+}
+
+// Router returns the httprouter.Router serving the tally routes.
+func (s *Server) Router() *httprouter.Router {
+	r := httprouter.New()
+	r.GET("/shifts/:scheduleID", s.handleShifts)
+	return r
+}
+
+func (s *Server) handleShifts(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	scheduleID := ps.ByName("scheduleID")
+
+	start, err := time.Parse(time.RFC3339, r.URL.Query().Get("start"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid start: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	end, err := time.Parse(time.RFC3339, r.URL.Query().Get("end"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid end: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	cal, err := s.calendarFor(start, end)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	_, userShifts, err := pd.ReadShifts(s.pdClient, s.conf, cal, scheduleID, start, end)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	fo, _ := outputs.CalculateFinalOutput(pd.ScheduleUserShifts{scheduleID: userShifts})
+
+	switch format {
+	case "json":
+		writeJSON(w, fo)
+	case "csv":
+		writeCSV(w, fo)
+	case "text":
+		writeText(w, fo)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+	}
+}
+
+// calendarFor returns the Calendar for [start, end), reusing a previously
+// built one (and its parsed holiday feed) for the same range so repeated
+// queries within a month don't refetch it. The holiday fetch itself runs
+// outside s.mu, so a slow or failing fetch for one range doesn't block
+// requests for other, already-cached ranges.
+func (s *Server) calendarFor(start, end time.Time) (*calendar.Calendar, error) {
+	key := start.Format(time.RFC3339) + ".." + end.Format(time.RFC3339)
+
+	s.mu.Lock()
+	cal, ok := s.cache[key]
+	s.mu.Unlock()
+	if ok {
+		return cal, nil
+	}
+
+	cal, err := calendar.NewCalendarWithError(start, end, s.conf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build calendar for %s: %w", key, err)
+	}
+
+	s.mu.Lock()
+	if existing, ok := s.cache[key]; ok {
+		cal = existing
+	} else {
+		s.cache[key] = cal
+	}
+	s.mu.Unlock()
+	return cal, nil
+}
+
+func writeJSON(w http.ResponseWriter, fo map[string]outputs.FinalOutput) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(fo); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func writeCSV(w http.ResponseWriter, fo map[string]outputs.FinalOutput) {
+	w.Header().Set("Content-Type", "text/csv")
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"user", "business hours", "afterhours", "weekend hours", "stat day hours", "total hours", "shifts", "total duration oncall"})
+	for user, o := range fo {
+		cw.Write([]string{
+			user,
+			fmt.Sprint(o.BusinessHours), fmt.Sprint(o.AfterHours), fmt.Sprint(o.WeekendHours),
+			fmt.Sprint(o.StatHours), fmt.Sprint(o.TotalHours), fmt.Sprint(o.TotalShifts),
+			o.TotalDuration.String(),
+		})
+	}
+	cw.Flush()
+}
+
+func writeText(w http.ResponseWriter, fo map[string]outputs.FinalOutput) {
+	w.Header().Set("Content-Type", "text/plain")
+	var buf bytes.Buffer
+	for user, o := range fo {
+		fmt.Fprintf(&buf, "User: %s\nBusinessHours: %d\tAfterHours: %d\nWeekendHours: %d\tStatDaysHours: %d\n"+
+			"\nTotal Hours: %d\tTotal Shifts: %d\nTotal Duration on-call: %s\n\n",
+			user, o.BusinessHours, o.AfterHours, o.WeekendHours, o.StatHours, o.TotalHours, o.TotalShifts, o.TotalDuration.String())
+	}
+	w.Write(buf.Bytes())
+}
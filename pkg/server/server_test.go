@@ -0,0 +1,36 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leosunmo/pagertally/pkg/config"
+)
+
+func TestCalendarForCachesByRange(t *testing.T) {
+	s := New(nil, &config.ScheduleConfig{})
+
+	start := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	first, err := s.calendarFor(start, end)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	second, err := s.calendarFor(start, end)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if first != second {
+		t.Error("expected the same range to return the cached Calendar instance")
+	}
+
+	otherEnd := end.AddDate(0, 1, 0)
+	third, err := s.calendarFor(start, otherEnd)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+	if third == first {
+		t.Error("expected a different range to build a new Calendar instance")
+	}
+}